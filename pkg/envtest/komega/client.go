@@ -0,0 +1,226 @@
+package komega
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Komega allows to Get, List and Update any client.Object and client.ObjectList,
+// either against the package-global default client (see SetClient) or against a
+// client.Client of the caller's choosing (see New).
+//
+// It exists so tests that need several independently-configured clients -- for
+// example an envtest client alongside a fake client, or the same client scoped to
+// different namespaces -- don't have to serialize access to a single global.
+type Komega interface {
+	// Get returns a function that fetches a resource and returns the occurring error.
+	// It can be used with gomega.Eventually() like this:
+	//   deployment := appsv1.Deployment{ ... }
+	//   gomega.Eventually(k.Get(&deployment)).To(gomega.Succeed())
+	// By calling the returned function directly it can also be used with gomega.Expect(k.Get(...)()).To(...)
+	Get(obj client.Object) func() error
+
+	// List returns a function that lists resources and returns the occurring error.
+	// It can be used with gomega.Eventually() like this:
+	//   deployments := v1.DeploymentList{ ... }
+	//   gomega.Eventually(k.List(&deployments)).To(gomega.Succeed())
+	// By calling the returned function directly it can also be used as gomega.Expect(k.List(...)()).To(...)
+	List(obj client.ObjectList, opts ...client.ListOption) func() error
+
+	// Update returns a function that fetches a resource, applies the provided update function and then updates the resource.
+	// It can be used with gomega.Eventually() like this:
+	//   deployment := appsv1.Deployment{ ... }
+	//   gomega.Eventually(k.Update(&deployment, func() {
+	//     deployment.Spec.Replicas = 3
+	//   })).To(gomega.Succeed())
+	// By calling the returned function directly it can also be used as gomega.Expect(k.Update(...)()).To(...)
+	Update(obj client.Object, f func(), opts ...client.UpdateOption) func() error
+
+	// UpdateStatus returns a function that fetches a resource, applies the provided update function and then updates the resource's status.
+	// It can be used with gomega.Eventually() like this:
+	//   deployment := appsv1.Deployment{ ... }
+	//   gomega.Eventually(k.UpdateStatus(&deployment, func() {
+	//     deployment.Status.AvailableReplicas = 1
+	//   })).To(gomega.Succeed())
+	// By calling the returned function directly it can also be used as gomega.Expect(k.UpdateStatus(...)()).To(...)
+	UpdateStatus(obj client.Object, f func(), opts ...client.UpdateOption) func() error
+
+	// Patch returns a function that fetches a resource, applies the provided update
+	// function and then patches the resource, using the patch mechanism selected
+	// by opts (a merge patch by default). See the package-level Patch function.
+	Patch(obj client.Object, f func(), opts ...PatchOption) func() error
+
+	// PatchStatus is to Patch what UpdateStatus is to Update: it patches the
+	// resource's status sub-resource instead of the resource itself.
+	PatchStatus(obj client.Object, f func(), opts ...PatchOption) func() error
+
+	// WithContext returns a Komega whose calls are issued using the given context
+	// instead of context.Background().
+	WithContext(ctx context.Context) Komega
+
+	// WithNamespace returns a Komega that applies the given namespace to every
+	// object it fetches or lists, overriding any namespace already set on the object.
+	WithNamespace(namespace string) Komega
+
+	// WithRetries returns a Komega whose Update and UpdateStatus retry on
+	// conflict errors, re-fetching the object and re-applying the mutation
+	// function, up to n additional times, similar to retry.RetryOnConflict.
+	WithRetries(n int) Komega
+}
+
+// komega is the default implementation of Komega, backed by a client.Client.
+type komega struct {
+	client    client.Client
+	ctx       context.Context
+	namespace string
+	retries   int
+}
+
+// New creates a new Komega instance using the given client.
+func New(c client.Client) Komega {
+	return &komega{
+		client: c,
+		ctx:    context.Background(),
+	}
+}
+
+func (k *komega) WithContext(ctx context.Context) Komega {
+	newK := *k
+	newK.ctx = ctx
+	return &newK
+}
+
+func (k *komega) WithNamespace(namespace string) Komega {
+	newK := *k
+	newK.namespace = namespace
+	return &newK
+}
+
+func (k *komega) WithRetries(n int) Komega {
+	newK := *k
+	newK.retries = n
+	return &newK
+}
+
+// retryOnConflict runs fn, re-running it on apierrors.IsConflict errors up to
+// k.retries additional times. With the default retries of 0 it behaves exactly
+// like calling fn once.
+func (k *komega) retryOnConflict(fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= k.retries; attempt++ {
+		if err = fn(); err == nil || !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (k *komega) applyNamespace(obj client.Object) {
+	if k.namespace != "" {
+		obj.SetNamespace(k.namespace)
+	}
+}
+
+func (k *komega) checkClient() {
+	if k.client == nil {
+		panic("Komega's client is not set. Use SetClient or New to set it.")
+	}
+}
+
+func (k *komega) Get(obj client.Object) func() error {
+	k.checkClient()
+	k.applyNamespace(obj)
+	return func() error {
+		return k.client.Get(k.ctx, client.ObjectKeyFromObject(obj), obj)
+	}
+}
+
+func (k *komega) List(obj client.ObjectList, opts ...client.ListOption) func() error {
+	k.checkClient()
+	if k.namespace != "" {
+		opts = append(opts, client.InNamespace(k.namespace))
+	}
+	return func() error {
+		return k.client.List(k.ctx, obj, opts...)
+	}
+}
+
+func (k *komega) Update(obj client.Object, f func(), opts ...client.UpdateOption) func() error {
+	k.checkClient()
+	k.applyNamespace(obj)
+	return func() error {
+		return k.retryOnConflict(func() error {
+			if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+			f()
+			return k.client.Update(k.ctx, obj, opts...)
+		})
+	}
+}
+
+func (k *komega) UpdateStatus(obj client.Object, f func(), opts ...client.UpdateOption) func() error {
+	k.checkClient()
+	k.applyNamespace(obj)
+	return func() error {
+		return k.retryOnConflict(func() error {
+			if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+			f()
+			return k.client.Status().Update(k.ctx, obj, toSubResourceUpdateOpts(opts)...)
+		})
+	}
+}
+
+// toSubResourceUpdateOpts converts client.UpdateOption values to the
+// client.SubResourceUpdateOption values expected by client.SubResourceWriter.Update.
+// Every option produced by the client package's With* constructors implements both
+// interfaces, so the type assertion below always succeeds.
+func toSubResourceUpdateOpts(opts []client.UpdateOption) []client.SubResourceUpdateOption {
+	out := make([]client.SubResourceUpdateOption, len(opts))
+	for i, opt := range opts {
+		out[i] = opt.(client.SubResourceUpdateOption)
+	}
+	return out
+}
+
+func (k *komega) Patch(obj client.Object, f func(), opts ...PatchOption) func() error {
+	k.checkClient()
+	k.applyNamespace(obj)
+	cfg := newPatchConfig(opts)
+	return func() error {
+		if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		original := obj.DeepCopyObject().(client.Object)
+		f()
+		return k.client.Patch(k.ctx, obj, cfg.patchFrom(original), cfg.opts...)
+	}
+}
+
+func (k *komega) PatchStatus(obj client.Object, f func(), opts ...PatchOption) func() error {
+	k.checkClient()
+	k.applyNamespace(obj)
+	cfg := newPatchConfig(opts)
+	return func() error {
+		if err := k.client.Get(k.ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		original := obj.DeepCopyObject().(client.Object)
+		f()
+		return k.client.Status().Patch(k.ctx, obj, cfg.patchFrom(original), toSubResourcePatchOpts(cfg.opts)...)
+	}
+}
+
+// toSubResourcePatchOpts is the Patch analogue of toSubResourceUpdateOpts.
+func toSubResourcePatchOpts(opts []client.PatchOption) []client.SubResourcePatchOption {
+	out := make([]client.SubResourcePatchOption, len(opts))
+	for i, opt := range opts {
+		out[i] = opt.(client.SubResourcePatchOption)
+	}
+	return out
+}
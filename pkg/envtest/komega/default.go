@@ -15,20 +15,14 @@ type ObjList[V any] interface {
 	client.ObjectList
 }
 
-type GenericUpdateFunc[T client.Object] func(T client.Object)
+type GenericUpdateFunc[T client.Object] func(obj T)
 
 // defaultK is the Komega used by the package global functions.
-var defaultK = &komega{}
+var defaultK Komega = New(nil)
 
-// SetDefaultClient sets the client used by the package global functions.
+// SetClient sets the client used by the package global functions.
 func SetClient(c client.Client) {
-	defaultK = &komega{client: c}
-}
-
-func checkClient() {
-	if defaultK.client == nil {
-		panic("Komega's client is not set. Use SetClient to set it.")
-	}
+	defaultK = New(c)
 }
 
 // Get returns a function that fetches a resource and returns the occurring error.
@@ -37,7 +31,6 @@ func checkClient() {
 //   gomega.Eventually(komega.Get(&deployment)).To(gomega.Succeed())
 // By calling the returned function directly it can also be used with gomega.Expect(komega.Get(...)()).To(...)
 func Get(obj client.Object) func() error {
-	checkClient()
 	return defaultK.Get(obj)
 }
 
@@ -47,7 +40,6 @@ func Get(obj client.Object) func() error {
 //   gomega.Eventually(k.List(&deployments)).To(gomega.Succeed())
 // By calling the returned function directly it can also be used as gomega.Expect(k.List(...)()).To(...)
 func List(obj client.ObjectList, opts ...client.ListOption) func() error {
-	checkClient()
 	return defaultK.List(obj, opts...)
 }
 
@@ -60,11 +52,7 @@ func List(obj client.ObjectList, opts ...client.ListOption) func() error {
 //   })).To(gomega.Scucceed())
 // By calling the returned function directly it can also be used as gomega.Expect(k.Update(...)()).To(...)
 func Update[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
-	checkClient()
-	var obj T = new(V)
-	obj.SetName(nn.Name)
-	obj.SetNamespace(nn.Namespace)
-	return defaultK.Update(obj, func() { f(obj) }, opts...)
+	return UpdateOf(defaultK, nn, f, opts...)
 }
 
 // UpdateStatus returns a function that fetches a resource, applies the provided update function and then updates the resource's status.
@@ -76,11 +64,26 @@ func Update[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], op
 //   })).To(gomega.Scucceed())
 // By calling the returned function directly it can also be used as gomega.Expect(k.UpdateStatus(...)()).To(...)
 func UpdateStatus[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
-	checkClient()
-	var obj T = new(V)
-	obj.SetName(nn.Name)
-	obj.SetNamespace(nn.Namespace)
-	return defaultK.UpdateStatus(obj, func() { f(obj) }, opts...)
+	return UpdateStatusOf(defaultK, nn, f, opts...)
+}
+
+// DefaultEventualRetries is the retry budget used by EventuallyUpdate and
+// EventuallyUpdateStatus.
+const DefaultEventualRetries = 4
+
+// EventuallyUpdate is like Update, but retries on conflict errors by
+// re-fetching the resource and re-applying f, up to DefaultEventualRetries
+// additional times within a single invocation. Use it for objects that are
+// also being written by something else (e.g. a controller under test),
+// where Update would otherwise need to be paired with a caller-managed retry
+// loop to survive occasional conflicts.
+func EventuallyUpdate[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
+	return UpdateOf(defaultK.WithRetries(DefaultEventualRetries), nn, f, opts...)
+}
+
+// EventuallyUpdateStatus is to UpdateStatus what EventuallyUpdate is to Update.
+func EventuallyUpdateStatus[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
+	return UpdateStatusOf(defaultK.WithRetries(DefaultEventualRetries), nn, f, opts...)
 }
 
 // Object returns a function that fetches a resource and returns the object.
@@ -89,14 +92,7 @@ func UpdateStatus[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[
 //   gomega.Eventually(k.Object(&deployment)).To(HaveField("Spec.Replicas", gomega.Equal(pointer.Int32(3))))
 // By calling the returned function directly it can also be used as gomega.Expect(k.Object(...)()).To(...)
 func Object[T Obj[V], V any](nn types.NamespacedName) func() (T, error) {
-	checkClient()
-	return func() (T, error) {
-		var obj T = new(V)
-		obj.SetName(nn.Name)
-		obj.SetNamespace(nn.Namespace)
-		err := defaultK.Get(obj)()
-		return obj, err
-	}
+	return ObjectOf[T, V](defaultK, nn)
 }
 
 // ObjectList returns a function that fetches a resource and returns the object.
@@ -105,10 +101,43 @@ func Object[T Obj[V], V any](nn types.NamespacedName) func() (T, error) {
 //   gomega.Eventually(k.ObjectList(&deployments)).To(HaveField("Items", HaveLen(1)))
 // By calling the returned function directly it can also be used as gomega.Expect(k.ObjectList(...)()).To(...)
 func ObjectList[T ObjList[V], V client.ObjectList](opts ...client.ListOption) func() (T, error) {
-	checkClient()
+	return ObjectListOf[T, V](defaultK, opts...)
+}
+
+// ObjectOf is the Komega-scoped form of Object: it behaves the same but operates
+// against the given Komega instead of the package-global default, so independent
+// subtests can each hold their own Komega.
+func ObjectOf[T Obj[V], V any](k Komega, nn types.NamespacedName) func() (T, error) {
+	return func() (T, error) {
+		var obj T = new(V)
+		obj.SetName(nn.Name)
+		obj.SetNamespace(nn.Namespace)
+		err := k.Get(obj)()
+		return obj, err
+	}
+}
+
+// ObjectListOf is the Komega-scoped form of ObjectList. See ObjectOf.
+func ObjectListOf[T ObjList[V], V client.ObjectList](k Komega, opts ...client.ListOption) func() (T, error) {
 	return func() (T, error) {
 		var obj T = new(V)
-		err := defaultK.List(obj, opts...)()
+		err := k.List(obj, opts...)()
 		return obj, err
 	}
 }
+
+// UpdateOf is the Komega-scoped form of Update. See ObjectOf.
+func UpdateOf[T Obj[V], V any](k Komega, nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
+	var obj T = new(V)
+	obj.SetName(nn.Name)
+	obj.SetNamespace(nn.Namespace)
+	return k.Update(obj, func() { f(obj) }, opts...)
+}
+
+// UpdateStatusOf is the Komega-scoped form of UpdateStatus. See ObjectOf.
+func UpdateStatusOf[T Obj[V], V any](k Komega, nn types.NamespacedName, f GenericUpdateFunc[T], opts ...client.UpdateOption) func() error {
+	var obj T = new(V)
+	obj.SetName(nn.Name)
+	obj.SetNamespace(nn.Namespace)
+	return k.UpdateStatus(obj, func() { f(obj) }, opts...)
+}
@@ -6,10 +6,24 @@ import (
 	. "github.com/onsi/gomega"
 
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+// createFakeClient returns a fake client seeded with a "test" Deployment in
+// the "default" namespace, for use by the tests in this package.
+func createFakeClient() client.Client {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(5)},
+	}
+	return fake.NewClientBuilder().WithObjects(dep).WithStatusSubresource(dep).Build()
+}
+
 func TestDefaultObject(t *testing.T) {
 	g := NewWithT(t)
 
@@ -21,3 +35,14 @@ func TestDefaultObject(t *testing.T) {
 		HaveField("Spec.Replicas", Equal(pointer.Int32(5))),
 	))
 }
+
+func TestScopedObject(t *testing.T) {
+	g := NewWithT(t)
+
+	k := New(createFakeClient())
+
+	g.Eventually(ObjectOf[*appsv1.Deployment](k, types.NamespacedName{Namespace: "default", Name: "test"})).Should(And(
+		Not(BeNil()),
+		HaveField("Spec.Replicas", Equal(pointer.Int32(5))),
+	))
+}
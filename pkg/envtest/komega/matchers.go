@@ -0,0 +1,180 @@
+package komega
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conditionsGetter is implemented by any object whose status exposes a
+// standard []metav1.Condition accessor, e.g. via a generated GetConditions method.
+type conditionsGetter interface {
+	GetConditions() []metav1.Condition
+}
+
+// extractConditions returns the []metav1.Condition found on actual, either through
+// a GetConditions() method or, failing that, by reflecting a Status.Conditions field.
+// This lets the matchers in this file work against any CRD that follows the
+// standard status-condition convention, without requiring generated accessors.
+func extractConditions(actual interface{}) ([]metav1.Condition, error) {
+	if cg, ok := actual.(conditionsGetter); ok {
+		return cg.GetConditions(), nil
+	}
+
+	v := reflect.ValueOf(actual)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("expected a non-nil object, got nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct or a GetConditions() []metav1.Condition, got %T", actual)
+	}
+
+	status := v.FieldByName("Status")
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%T has no Status field", actual)
+	}
+	for status.Kind() == reflect.Ptr {
+		if status.IsNil() {
+			return nil, fmt.Errorf("%T's Status is nil", actual)
+		}
+		status = status.Elem()
+	}
+	if status.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T's Status is not a struct", actual)
+	}
+	conditions := status.FieldByName("Conditions")
+	if !conditions.IsValid() {
+		return nil, fmt.Errorf("%T's Status has no Conditions field", actual)
+	}
+	out, ok := conditions.Interface().([]metav1.Condition)
+	if !ok {
+		return nil, fmt.Errorf("%T's Status.Conditions is not a []metav1.Condition", actual)
+	}
+	return out, nil
+}
+
+// HaveCondition returns a matcher that succeeds if actual has a status condition
+// of the given type whose Status matches. actual may be a client.Object (or
+// pointer to one) whose Status.Conditions is a []metav1.Condition, or anything
+// implementing GetConditions() []metav1.Condition. It is meant to be used with
+// Object, e.g.:
+//   g.Eventually(k.Object(obj)).Should(k.HaveCondition("Ready", metav1.ConditionTrue))
+func HaveCondition(conditionType string, status metav1.ConditionStatus) types.GomegaMatcher {
+	return &conditionMatcher{conditionType: conditionType, status: &status}
+}
+
+// HaveConditionReason returns a matcher that succeeds if actual has a status
+// condition of the given type whose Reason matches. See HaveCondition.
+func HaveConditionReason(conditionType, reason string) types.GomegaMatcher {
+	return &conditionMatcher{conditionType: conditionType, reason: &reason}
+}
+
+// HaveObservedGeneration returns a matcher that succeeds if every status
+// condition on actual has an ObservedGeneration equal to actual's own
+// metadata.generation. It catches the common controller bug of updating status
+// without bumping ObservedGeneration after the spec changed.
+func HaveObservedGeneration() types.GomegaMatcher {
+	return &observedGenerationMatcher{}
+}
+
+type conditionMatcher struct {
+	conditionType string
+	status        *metav1.ConditionStatus
+	reason        *string
+
+	found *metav1.Condition
+}
+
+func (m *conditionMatcher) Match(actual interface{}) (bool, error) {
+	conditions, err := extractConditions(actual)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range conditions {
+		if conditions[i].Type != m.conditionType {
+			continue
+		}
+		m.found = &conditions[i]
+		if m.status != nil {
+			return conditions[i].Status == *m.status, nil
+		}
+		if m.reason != nil {
+			return conditions[i].Reason == *m.reason, nil
+		}
+		return true, nil
+	}
+
+	m.found = nil
+	return false, nil
+}
+
+func (m *conditionMatcher) FailureMessage(actual interface{}) string {
+	if m.found == nil {
+		return fmt.Sprintf("Expected to find condition of type %q, found none", m.conditionType)
+	}
+	if m.status != nil {
+		return fmt.Sprintf("Expected condition %q to have status %q, got %q", m.conditionType, *m.status, m.found.Status)
+	}
+	return fmt.Sprintf("Expected condition %q to have reason %q, got %q", m.conditionType, *m.reason, m.found.Reason)
+}
+
+func (m *conditionMatcher) NegatedFailureMessage(actual interface{}) string {
+	if m.status != nil {
+		return fmt.Sprintf("Expected condition %q not to have status %q", m.conditionType, *m.status)
+	}
+	return fmt.Sprintf("Expected condition %q not to have reason %q", m.conditionType, *m.reason)
+}
+
+type observedGenerationMatcher struct {
+	stale []metav1.Condition
+}
+
+func (m *observedGenerationMatcher) Match(actual interface{}) (bool, error) {
+	conditions, err := extractConditions(actual)
+	if err != nil {
+		return false, err
+	}
+
+	obj, ok := actual.(interface{ GetGeneration() int64 })
+	if !ok {
+		v := reflect.ValueOf(actual)
+		for v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		return false, fmt.Errorf("%s does not expose GetGeneration()", v.Type())
+	}
+	generation := obj.GetGeneration()
+
+	m.stale = nil
+	for _, c := range conditions {
+		if c.ObservedGeneration != generation {
+			m.stale = append(m.stale, c)
+		}
+	}
+	return len(m.stale) == 0, nil
+}
+
+func (m *observedGenerationMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected all conditions to have an up to date ObservedGeneration, the following did not: %v", m.stale)
+}
+
+func (m *observedGenerationMatcher) NegatedFailureMessage(actual interface{}) string {
+	return "Expected at least one condition to have a stale ObservedGeneration"
+}
+
+// WithConsistentDuration returns a Consistently assertion that polls actual and
+// requires it to keep satisfying the eventual matcher for the given duration. It
+// is meant to chain into the matchers in this file, e.g.:
+//   k.WithConsistentDuration(5*time.Second, k.Object(obj)).Should(k.HaveCondition("Ready", metav1.ConditionTrue))
+func WithConsistentDuration(duration time.Duration, actual interface{}) gomega.AsyncAssertion {
+	return gomega.Consistently(actual, duration)
+}
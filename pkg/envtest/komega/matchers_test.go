@@ -0,0 +1,62 @@
+package komega
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeStatus struct {
+	Conditions []metav1.Condition
+}
+
+type fakeObj struct {
+	metav1.ObjectMeta
+	Status fakeStatus
+}
+
+// fakePtrStatusObj mirrors the common kubebuilder pattern of a pointer-typed
+// Status field, e.g. `Status *FooStatus`.
+type fakePtrStatusObj struct {
+	metav1.ObjectMeta
+	Status *fakeStatus
+}
+
+func TestHaveCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &fakeObj{Status: fakeStatus{Conditions: []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "AllGood", ObservedGeneration: 2},
+	}}}
+	obj.Generation = 2
+
+	g.Expect(obj).To(HaveCondition("Ready", metav1.ConditionTrue))
+	g.Expect(obj).NotTo(HaveCondition("Ready", metav1.ConditionFalse))
+	g.Expect(obj).To(HaveConditionReason("Ready", "AllGood"))
+	g.Expect(obj).To(HaveObservedGeneration())
+}
+
+func TestHaveObservedGenerationStale(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &fakeObj{Status: fakeStatus{Conditions: []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 1},
+	}}}
+	obj.Generation = 2
+
+	g.Expect(obj).NotTo(HaveObservedGeneration())
+}
+
+func TestHaveConditionPointerStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &fakePtrStatusObj{Status: &fakeStatus{Conditions: []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "AllGood", ObservedGeneration: 2},
+	}}}
+	obj.Generation = 2
+
+	g.Expect(obj).To(HaveCondition("Ready", metav1.ConditionTrue))
+	g.Expect(obj).To(HaveObservedGeneration())
+}
@@ -0,0 +1,86 @@
+package komega
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// patchConfig holds the patch mechanism selected via PatchOption.
+type patchConfig struct {
+	patchFrom func(client.Object) client.Patch
+	opts      []client.PatchOption
+}
+
+func newPatchConfig(opts []PatchOption) *patchConfig {
+	cfg := &patchConfig{patchFrom: client.MergeFrom}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// PatchOption configures how Patch and PatchStatus derive the patch they send
+// to the API server from the object's pre-mutation snapshot.
+type PatchOption func(*patchConfig)
+
+// WithMergePatch selects a JSON merge patch (client.MergeFrom). This is the default.
+func WithMergePatch() PatchOption {
+	return func(c *patchConfig) { c.patchFrom = client.MergeFrom }
+}
+
+// WithStrategicMergePatch selects a strategic merge patch (client.StrategicMergeFrom).
+func WithStrategicMergePatch() PatchOption {
+	return func(c *patchConfig) {
+		c.patchFrom = func(obj client.Object) client.Patch { return client.StrategicMergeFrom(obj) }
+	}
+}
+
+// WithApplyPatch selects server-side apply (client.Apply), owned by the given
+// field manager. The mutated object must be valid input for server-side apply.
+func WithApplyPatch(fieldManager string, force bool) PatchOption {
+	return func(c *patchConfig) {
+		c.patchFrom = func(client.Object) client.Patch { return client.Apply }
+		c.opts = append(c.opts, client.FieldOwner(fieldManager))
+		if force {
+			c.opts = append(c.opts, client.ForceOwnership)
+		}
+	}
+}
+
+// Patch returns a function that fetches a resource, applies the provided update
+// function and then patches the resource using the selected patch mechanism
+// (merge patch by default). Unlike Update, it does not replace the full object,
+// so it doesn't lose to concurrent writers the way a full update does.
+// It can be used with gomega.Eventually() like this:
+//   nn := types.NamespacedName{Namespace: "default", Name: "my-deployment"}
+//   gomega.Eventually(komega.Patch(nn, func(d *appsv1.Deployment) {
+//     d.Spec.Replicas = pointer.Int32(3)
+//   })).To(gomega.Succeed())
+// By calling the returned function directly it can also be used as gomega.Expect(komega.Patch(...)()).To(...)
+func Patch[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...PatchOption) func() error {
+	return PatchOf(defaultK, nn, f, opts...)
+}
+
+// PatchStatus returns a function that fetches a resource, applies the provided
+// update function and then patches the resource's status using the selected
+// patch mechanism (merge patch by default). See Patch.
+func PatchStatus[T Obj[V], V any](nn types.NamespacedName, f GenericUpdateFunc[T], opts ...PatchOption) func() error {
+	return PatchStatusOf(defaultK, nn, f, opts...)
+}
+
+// PatchOf is the Komega-scoped form of Patch. See ObjectOf.
+func PatchOf[T Obj[V], V any](k Komega, nn types.NamespacedName, f GenericUpdateFunc[T], opts ...PatchOption) func() error {
+	var obj T = new(V)
+	obj.SetName(nn.Name)
+	obj.SetNamespace(nn.Namespace)
+	return k.Patch(obj, func() { f(obj) }, opts...)
+}
+
+// PatchStatusOf is the Komega-scoped form of PatchStatus. See ObjectOf.
+func PatchStatusOf[T Obj[V], V any](k Komega, nn types.NamespacedName, f GenericUpdateFunc[T], opts ...PatchOption) func() error {
+	var obj T = new(V)
+	obj.SetName(nn.Name)
+	obj.SetNamespace(nn.Namespace)
+	return k.PatchStatus(obj, func() { f(obj) }, opts...)
+}
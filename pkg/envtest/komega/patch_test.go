@@ -0,0 +1,56 @@
+package komega
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func TestPatch(t *testing.T) {
+	g := NewWithT(t)
+
+	k := New(createFakeClient())
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	g.Eventually(PatchOf[*appsv1.Deployment](k, nn, func(d *appsv1.Deployment) {
+		d.Spec.Replicas = pointer.Int32(7)
+	})).Should(Succeed())
+
+	g.Eventually(ObjectOf[*appsv1.Deployment](k, nn)).Should(
+		HaveField("Spec.Replicas", Equal(pointer.Int32(7))),
+	)
+}
+
+func TestPatchWithStrategicMergePatch(t *testing.T) {
+	g := NewWithT(t)
+
+	k := New(createFakeClient())
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	g.Eventually(PatchOf[*appsv1.Deployment](k, nn, func(d *appsv1.Deployment) {
+		d.Spec.Replicas = pointer.Int32(8)
+	}, WithStrategicMergePatch())).Should(Succeed())
+
+	g.Eventually(ObjectOf[*appsv1.Deployment](k, nn)).Should(
+		HaveField("Spec.Replicas", Equal(pointer.Int32(8))),
+	)
+}
+
+func TestPatchWithApplyPatch(t *testing.T) {
+	g := NewWithT(t)
+
+	k := New(createFakeClient())
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	g.Eventually(PatchOf[*appsv1.Deployment](k, nn, func(d *appsv1.Deployment) {
+		d.Spec.Replicas = pointer.Int32(9)
+	}, WithApplyPatch("komega-test", true))).Should(Succeed())
+
+	g.Eventually(ObjectOf[*appsv1.Deployment](k, nn)).Should(
+		HaveField("Spec.Replicas", Equal(pointer.Int32(9))),
+	)
+}
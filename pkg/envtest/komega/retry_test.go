@@ -0,0 +1,78 @@
+package komega
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictingClient fails the first `failures` Update calls with a Conflict error.
+type conflictingClient struct {
+	client.Client
+	failures int
+}
+
+func (c *conflictingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.failures > 0 {
+		c.failures--
+		return apierrors.NewConflict(schema.GroupResource{Resource: "deployments"}, obj.GetName(), nil)
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestEventuallyUpdateRetriesOnConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	cc := &conflictingClient{Client: createFakeClient(), failures: 2}
+	k := New(cc)
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	// A single invocation must absorb both conflicts itself.
+	g.Expect(UpdateOf(k.WithRetries(2), nn, func(d *appsv1.Deployment) {
+		d.Annotations = map[string]string{"retried": "true"}
+	})()).To(Succeed())
+
+	dep, err := ObjectOf[*appsv1.Deployment](k, nn)()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dep.Annotations).To(HaveKeyWithValue("retried", "true"))
+}
+
+func TestEventuallyUpdatePackageLevelRetriesOnConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	cc := &conflictingClient{Client: createFakeClient(), failures: 2}
+	SetClient(cc)
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	// A single invocation must absorb both conflicts itself.
+	g.Expect(EventuallyUpdate(nn, func(d *appsv1.Deployment) {
+		d.Annotations = map[string]string{"retried": "true"}
+	})()).To(Succeed())
+
+	dep, err := Object[*appsv1.Deployment](nn)()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dep.Annotations).To(HaveKeyWithValue("retried", "true"))
+}
+
+func TestEventuallyUpdateStatusPackageLevel(t *testing.T) {
+	g := NewWithT(t)
+
+	SetClient(createFakeClient())
+	nn := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	g.Eventually(EventuallyUpdateStatus(nn, func(d *appsv1.Deployment) {
+		d.Status.AvailableReplicas = 5
+	})).Should(Succeed())
+
+	dep, err := Object[*appsv1.Deployment](nn)()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(dep.Status.AvailableReplicas).To(Equal(int32(5)))
+}